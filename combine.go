@@ -1,42 +1,103 @@
 package traces
 
-// Combine returns a new Series that is the result of appling function f to
+import "iter"
+
+// Combine returns a new Series that is the result of applying function f to
 // all the points in all the series in the list.
 //
+// Combine does a k-way merge across the inputs' All iterators rather than
+// materializing every key into a map up front: each input is pulled one
+// point at a time, and at each step only the smallest current x across all
+// of the inputs is consumed, so the result is built already in x-order.
+//
+// If list is empty, the result is a valid, empty Series with no comparator,
+// since there's no input series to infer an x ordering from; reading it
+// (Size, Xs, Get, ...) is fine, but Set-ing a point onto it and then
+// sorting or reading it panics.  Build an empty series with NewSeries or
+// NewSeriesFunc instead if you need one that's actually mutable.
+//
 // Helper functions are provided for adding, subtracting, any-ing and all-ing.
 // For example, to add the series s0, s1 and s2:
 //
-//   sum := traces.Combine(traces.Sum, s0, s1, s2)
+//   sum := traces.Combine(traces.Sum[int64], s0, s1, s2)
 //
-func Combine(f func(...int64) int64, list ...*Series) *Series {
+func Combine[X comparable, Y Number](f func(...Y) Y, list ...*Series[X, Y]) *Series[X, Y] {
 
-	points := make(map[int64]int64)
-	unsorted := make([]int64, 0)
+	// Take the comparator from the first input that actually has one.
+	// list[0] alone isn't good enough: it can be the nil-comparator
+	// Series that Combine itself returns for a zero-length list, even
+	// while later elements of list are perfectly good non-empty series.
+	var cmp func(a, b X) int
+	for _, s := range list {
+		if s.cmp != nil {
+			cmp = s.cmp
+			break
+		}
+	}
 
-	valueSet := make([]int64, len(list))
+	type cursor struct {
+		next func() (X, Y, bool)
+		stop func()
+		x    X
+		ok   bool
+	}
 
-	for _, s := range list {
-		for key := range s.points {
-			if _, ok := points[key]; !ok {
-				for i, t0 := range list {
-					valueSet[i] = t0.Get(key)
-				}
-				points[key] = f(valueSet...)
-				unsorted = append(unsorted, key)
+	cursors := make([]*cursor, len(list))
+	for i, s := range list {
+		next, stop := iter.Pull2(s.All())
+		c := &cursor{next: next, stop: stop}
+		c.x, _, c.ok = next()
+		cursors[i] = c
+	}
+	defer func() {
+		for _, c := range cursors {
+			c.stop()
+		}
+	}()
+
+	points := make(map[X]Y)
+	sorted := make([]X, 0)
+	valueSet := make([]Y, len(list))
+
+	for {
+		var (
+			minX X
+			has  bool
+		)
+		for _, c := range cursors {
+			if c.ok && (!has || cmp(c.x, minX) < 0) {
+				minX = c.x
+				has = true
+			}
+		}
+		if !has {
+			break
+		}
+
+		for i, t0 := range list {
+			valueSet[i] = t0.Get(minX)
+		}
+		points[minX] = f(valueSet...)
+		sorted = append(sorted, minX)
+
+		for _, c := range cursors {
+			if c.ok && cmp(c.x, minX) == 0 {
+				c.x, _, c.ok = c.next()
 			}
 		}
 	}
 
-	return &Series{
+	return &Series[X, Y]{
 		points:   points,
-		sorted:   make([]int64, 0),
-		unsorted: unsorted,
+		sorted:   sorted,
+		unsorted: make([]X, 0),
+		cmp:      cmp,
 	}
 }
 
 // Sum adds all the vals.  Use with the Combine function.
-func Sum(vals ...int64) int64 {
-	var sum int64
+func Sum[Y Number](vals ...Y) Y {
+	var sum Y
 	for _, val := range vals {
 		sum += val
 	}
@@ -45,8 +106,8 @@ func Sum(vals ...int64) int64 {
 
 // Diff subtracts from the first value all the remaining values.  Use with
 // the Combine function.
-func Diff(vals ...int64) int64 {
-	var diff int64
+func Diff[Y Number](vals ...Y) Y {
+	var diff Y
 	for i, val := range vals {
 		if i == 0 {
 			diff = val
@@ -59,8 +120,8 @@ func Diff(vals ...int64) int64 {
 
 // Any returns one if any of the values are nonzero and zero otherwise.
 // Use with the Combine function.
-func Any(vals ...int64) int64 {
-	var any int64
+func Any[Y Number](vals ...Y) Y {
+	var any Y
 	for _, val := range vals {
 		if val != 0 {
 			any = 1
@@ -71,8 +132,8 @@ func Any(vals ...int64) int64 {
 
 // All returns one if all of the values are nonzero and zero otherwise.
 // Use with the Combine function.
-func All(vals ...int64) int64 {
-	var all int64 = 1
+func All[Y Number](vals ...Y) Y {
+	var all Y = 1
 	for _, val := range vals {
 		if val == 0 {
 			all = 0
@@ -36,41 +36,57 @@ var combineTestCases = []combineTestCase{
 
 func TestCombine(t *testing.T) {
 	for _, c := range combineTestCases {
-		list := make([]*Series, len(c.Vals))
+		list := make([]*Int64Series, len(c.Vals))
 		for i, val := range c.Vals {
-			list[i] = NewSeriesData(val)
+			list[i] = NewInt64SeriesData(val)
 		}
 
-		sum := Combine(Sum, list...)
-		expectedSum := NewSeriesData(c.Sum)
+		sum := Combine(Sum[int64], list...)
+		expectedSum := NewInt64SeriesData(c.Sum)
 		expectedSum.Compact()
 		assertConsistent(t, sum)
 		sum.Compact()
 		assertConsistent(t, sum)
-		assert(t, "Combine as sum", expectedSum, sum)
+		assert(t, "Combine as sum", expectedSum.points, sum.points)
 
-		diff := Combine(Diff, list...)
-		expectedDiff := NewSeriesData(c.Diff)
+		diff := Combine(Diff[int64], list...)
+		expectedDiff := NewInt64SeriesData(c.Diff)
 		expectedDiff.Compact()
 		assertConsistent(t, diff)
 		diff.Compact()
 		assertConsistent(t, diff)
-		assert(t, "Combine as diff", expectedDiff, diff)
+		assert(t, "Combine as diff", expectedDiff.points, diff.points)
 
-		any := Combine(Any, list...)
-		expectedAny := NewSeriesData(c.Any)
+		any := Combine(Any[int64], list...)
+		expectedAny := NewInt64SeriesData(c.Any)
 		expectedAny.Compact()
 		assertConsistent(t, any)
 		any.Compact()
 		assertConsistent(t, any)
-		assert(t, "Combine as any", expectedAny, any)
+		assert(t, "Combine as any", expectedAny.points, any.points)
 
-		all := Combine(All, list...)
-		expectedAll := NewSeriesData(c.All)
+		all := Combine(All[int64], list...)
+		expectedAll := NewInt64SeriesData(c.All)
 		expectedAll.Compact()
 		assertConsistent(t, all)
 		all.Compact()
 		assertConsistent(t, all)
-		assert(t, "Combine as or", expectedAll, all)
+		assert(t, "Combine as or", expectedAll.points, all.points)
 	}
 }
+
+// TestCombineEmptyLeadingInput makes sure that feeding the nil-comparator
+// Series produced by an empty Combine call back in as list[0] of an outer
+// Combine, alongside two or more non-empty series, doesn't crash: the
+// comparator for the k-way merge has to come from the first input that
+// actually has one, not blindly from list[0].
+func TestCombineEmptyLeadingInput(t *testing.T) {
+	empty := Combine[int64, int64](Sum[int64])
+	s0 := NewInt64SeriesData(map[int64]int64{1: 10, 5: 20})
+	s1 := NewInt64SeriesData(map[int64]int64{2: 30, 6: 40})
+
+	sum := Combine(Sum[int64], empty, s0, s1)
+	assertConsistent(t, sum)
+	expected := NewInt64SeriesData(map[int64]int64{1: 10, 2: 40, 5: 50, 6: 60})
+	assert(t, "Combine with empty leading input", expected.points, sum.points)
+}
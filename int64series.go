@@ -0,0 +1,17 @@
+package traces
+
+// Int64Series is a Series over int64 x and y values.  It is the original
+// int64-only Series API, preserved as a thin alias now that Series is
+// generic over its x-axis and y-axis types.
+type Int64Series = Series[int64, int64]
+
+// NewInt64Series returns a new, empty Int64Series object.
+func NewInt64Series() *Int64Series {
+	return NewSeries[int64, int64]()
+}
+
+// NewInt64SeriesData returns a new Int64Series object prefilled with the
+// data in the map.
+func NewInt64SeriesData(data map[int64]int64) *Int64Series {
+	return NewSeriesData(data)
+}
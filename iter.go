@@ -0,0 +1,63 @@
+package traces
+
+import (
+	"iter"
+	"slices"
+)
+
+// All returns an iterator over all of s's stored points, in x-order.  Unlike
+// Xs followed by per-element Get calls, All walks s.sorted once after a
+// single sort, without allocating a copy or re-searching for each point.
+func (s *Series[X, Y]) All() iter.Seq2[X, Y] {
+	return func(yield func(X, Y) bool) {
+		s.sort()
+		for _, x := range s.sorted {
+			if !yield(x, s.points[x]) {
+				return
+			}
+		}
+	}
+}
+
+// Range returns an iterator over s's stored points with lo ≤ x ≤ hi, in
+// x-order.
+func (s *Series[X, Y]) Range(lo, hi X) iter.Seq2[X, Y] {
+	return s.RangeFunc(lo, hi, s.cmp)
+}
+
+// RangeFunc is like Range, but takes compare explicitly instead of reading
+// it off of s.  compare must order x values the same way s itself does
+// (i.e. the comparator s was built with via NewSeries or NewSeriesFunc);
+// passing a different ordering corrupts the binary search into s.sorted.
+func (s *Series[X, Y]) RangeFunc(lo, hi X, compare func(a, b X) int) iter.Seq2[X, Y] {
+	return func(yield func(X, Y) bool) {
+		s.sort()
+		i, _ := slices.BinarySearchFunc(s.sorted, lo, compare)
+		for ; i < len(s.sorted) && compare(s.sorted[i], hi) <= 0; i++ {
+			x := s.sorted[i]
+			if !yield(x, s.points[x]) {
+				return
+			}
+		}
+	}
+}
+
+// Step returns an iterator over f(lo), f(lo+step), f(lo+2·step), ... for as
+// long as x ≤ hi, using the step-function semantics f(x)=f(floor(x)).  Step
+// panics if step is not positive, since a zero or negative step would never
+// advance past hi.  It is a free function rather than a method because,
+// unlike All and Range, it needs to add step to x, which requires X to be a
+// Number; series with other x-axis types (e.g. time.Time) should walk Range
+// directly and compute the next x by hand.
+func Step[X Number, Y Number](s *Series[X, Y], lo, hi, step X) iter.Seq2[X, Y] {
+	if step <= 0 {
+		panic("traces: Step requires a positive step")
+	}
+	return func(yield func(X, Y) bool) {
+		for x := lo; x <= hi; x += step {
+			if !yield(x, s.Get(x)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package traces
+
+import "testing"
+
+func TestAll(t *testing.T) {
+	for _, c := range []testCase{case0, case1, case2, case3, casen, caser} {
+		s := NewInt64SeriesData(c.Points)
+		xs := s.Xs()
+		i := 0
+		for x, y := range s.All() {
+			if x != xs[i] {
+				t.Errorf("All x - expected %d, got %d", xs[i], x)
+			}
+			if expected := c.Points[x]; y != expected {
+				t.Errorf("All y(%d) - expected %d, got %d", x, expected, y)
+			}
+			i++
+		}
+		assert(t, "All length", len(xs), i)
+		assertConsistent(t, s)
+	}
+}
+
+func TestRange(t *testing.T) {
+	s := NewInt64SeriesData(caser.Points)
+	var got []int64
+	for x := range s.Range(2, 10) {
+		got = append(got, x)
+	}
+	want := []int64{2, 4, 5, 10}
+	assert(t, "Range", want, got)
+	assertConsistent(t, s)
+}
+
+func TestStep(t *testing.T) {
+	s := NewInt64SeriesData(caser.Points)
+	var got []int64
+	for _, y := range Step(s, 0, 10, 2) {
+		got = append(got, y)
+	}
+	want := []int64{0, 10, 10, 9, 9, 8}
+	assert(t, "Step", want, got)
+}
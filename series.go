@@ -1,100 +1,124 @@
 package traces
 
 import (
+	"cmp"
 	"reflect"
-	"sort"
+	"slices"
 )
 
+// Number is the set of numeric types that the Sum/Diff/Any/All combiners
+// (and any other arithmetic on y values) can operate on.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
 // Series represents a discrete function f(x)=y with a collection of
 // (x, y) pairs.  Each (x, y) pair represents a transition, i.e. if (x₀, y₀)
-// and (x₁, y₁) are consecute pairs, then f(x)=y₀ for x₀ ≤ x < x₁.
-// All x and y values are int64.
-type Series struct {
-	points   map[int64]int64
-	sorted   []int64
-	unsorted []int64
+// and (x₁, y₁) are consecutive pairs, then f(x)=y₀ for x₀ ≤ x < x₁.
+//
+// X is the type of the x-axis and Y is the type of the y-axis.  X must be
+// comparable so that points can be keyed by it, and Y must be a Number so
+// that Combine and its helpers can do arithmetic on it.
+type Series[X comparable, Y Number] struct {
+	points   map[X]Y
+	sorted   []X
+	unsorted []X
+	cmp      func(a, b X) int
 	// Internal consistency is always maintained such that all of the keys
 	// in the points map appear exactly once in either sorted or unsorted,
 	// and all of the keys in sorted are in order.
 }
 
-// NewSeries returns a new, empty Series object.
-func NewSeries() *Series {
-	return &Series{
-		points:   make(map[int64]int64),
-		sorted:   make([]int64, 0),
-		unsorted: make([]int64, 0),
+// NewSeries returns a new, empty Series object.  X must satisfy cmp.Ordered
+// so that points can be ordered with the natural < operator; use
+// NewSeriesFunc for x-axis types, such as time.Time, that don't.
+func NewSeries[X cmp.Ordered, Y Number]() *Series[X, Y] {
+	return &Series[X, Y]{
+		points:   make(map[X]Y),
+		sorted:   make([]X, 0),
+		unsorted: make([]X, 0),
+		cmp:      cmp.Compare[X],
 	}
 }
 
 // NewSeriesData returns a new Series object prefilled with the data in the map.
-func NewSeriesData(data map[int64]int64) *Series {
-	points := make(map[int64]int64)
-	unsorted := make([]int64, 0, len(points))
+func NewSeriesData[X cmp.Ordered, Y Number](data map[X]Y) *Series[X, Y] {
+	s := NewSeries[X, Y]()
 	for key, val := range data {
-		points[key] = val
-		unsorted = append(unsorted, key)
+		s.points[key] = val
+		s.unsorted = append(s.unsorted, key)
 	}
+	return s
+}
 
-	return &Series{
-		points:   points,
-		sorted:   make([]int64, 0),
-		unsorted: unsorted,
+// NewSeriesFunc returns a new, empty Series object that orders its x values
+// with compare instead of the natural < operator.  Use this for x-axis
+// types that aren't cmp.Ordered, such as time.Time.
+func NewSeriesFunc[X comparable, Y Number](compare func(a, b X) int) *Series[X, Y] {
+	return &Series[X, Y]{
+		points:   make(map[X]Y),
+		sorted:   make([]X, 0),
+		unsorted: make([]X, 0),
+		cmp:      compare,
 	}
 }
 
 // sort takes any unsorted keys in s.unsorted and merges them into s.sorted.
-func (s *Series) sort() {
+// Rather than resorting everything from scratch, it sorts the (typically
+// much smaller) unsorted slice on its own and then merges the two sorted
+// slices in a single linear pass, so a read following a handful of Set
+// calls on a large series stays close to O(n) instead of O(n log n).
+func (s *Series[X, Y]) sort() {
 	if len(s.unsorted) == 0 {
 		return
 	}
-	s.sorted = append(s.sorted, s.unsorted...)
-	s.unsorted = make([]int64, 0)
-	sort.Slice(s.sorted, func(i, j int) bool { return s.sorted[i] < s.sorted[j] })
-}
-
-// find finds and returns the largest index i into sorted such that
-// s.sorted[i] <= x.  Returns -1 if x < s.sorted[0] or if s.sorted is
-// empty.
-func (s *Series) find(x int64) int {
-
-	if len(s.sorted) == 0 || x < s.sorted[0] {
-		return -1
+	if s.cmp == nil {
+		panic("traces: series has no comparator to sort with (likely the result of Combine with no input series); build a mutable series with NewSeries or NewSeriesFunc instead")
 	}
+	slices.SortFunc(s.unsorted, s.cmp)
 
-	// i, j are bounds such that sorted[i] <= key < sorted[j].
-	// We will narrow the bounds until j-i is 1 or until we find
-	// the exact key.
-	i, j := 0, len(s.sorted)
-
-	for j-i > 1 {
-		half := (i + j + 1) / 2
-		if x == s.sorted[half] {
-			return half
-		} else if x > s.sorted[half] {
-			i = half
+	merged := make([]X, 0, len(s.sorted)+len(s.unsorted))
+	i, j := 0, 0
+	for i < len(s.sorted) && j < len(s.unsorted) {
+		if s.cmp(s.sorted[i], s.unsorted[j]) < 0 {
+			merged = append(merged, s.sorted[i])
+			i++
 		} else {
-			j = half
+			merged = append(merged, s.unsorted[j])
+			j++
 		}
 	}
+	merged = append(merged, s.sorted[i:]...)
+	merged = append(merged, s.unsorted[j:]...)
+
+	s.sorted = merged
+	s.unsorted = make([]X, 0)
+}
 
-	return i
+// find returns the result of a binary search for x in s.sorted: found
+// reports whether s.sorted[i] == x, and i is the index of x if found, or
+// otherwise the index at which x would have to be inserted to keep
+// s.sorted in order (i.e. the number of stored keys less than x).
+func (s *Series[X, Y]) find(x X) (i int, found bool) {
+	return slices.BinarySearchFunc(s.sorted, x, s.cmp)
 }
 
 // Size returns the number of stored points in the series.
-func (s *Series) Size() int {
+func (s *Series[X, Y]) Size() int {
 	return len(s.points)
 }
 
 // Has returns true if there is a stored point at x.
-func (s *Series) Has(x int64) bool {
+func (s *Series[X, Y]) Has(x X) bool {
 	_, ok := s.points[x]
 	return ok
 }
 
 // Set adds the point (x, y) to the series, replacing the existing point
 // at x if there is one.
-func (s *Series) Set(x, y int64) {
+func (s *Series[X, Y]) Set(x X, y Y) {
 	if _, ok := s.points[x]; !ok {
 		s.unsorted = append(s.unsorted, x)
 	}
@@ -103,27 +127,29 @@ func (s *Series) Set(x, y int64) {
 
 // Get retrieves the value f(x).  If x in not a stored point in the series,
 // then f(x) is defined as f(x₀) for the largest x₀ < x.  If there is no such
-// x₀, then f(x)=0.
-func (s *Series) Get(x int64) int64 {
+// x₀, then f(x) is the zero value of Y.
+func (s *Series[X, Y]) Get(x X) Y {
 	if y, ok := s.points[x]; ok {
 		return y
 	}
 	s.sort()
-	i := s.find(x)
-	if i < 0 {
-		return 0
+	i, _ := s.find(x) // x is never a stored point here, so never found.
+	if i == 0 {
+		var zero Y
+		return zero
 	}
-	return s.points[s.sorted[i]]
+	return s.points[s.sorted[i-1]]
 }
 
 // Remove removes the stored point at x from the series if it exists.
-func (s *Series) Remove(x int64) {
+func (s *Series[X, Y]) Remove(x X) {
 	if _, ok := s.points[x]; !ok {
 		return
 	}
 	s.sort()
-	i := s.find(x)
-	s.sorted = append(s.sorted[:i], s.sorted[i+1:]...)
+	if i, found := s.find(x); found {
+		s.sorted = append(s.sorted[:i], s.sorted[i+1:]...)
+	}
 	delete(s.points, x)
 }
 
@@ -132,13 +158,14 @@ func (s *Series) Remove(x int64) {
 // (x₀, y₀) and (x₁, y₁) such that y₀ = y₁.  Removing redundant points
 // does not affect the value of the function.
 //
-// Compact never removes the first point, even if the y value is 0.
-func (s *Series) Compact() {
+// Compact never removes the first point, even if the y value is the zero
+// value of Y.
+func (s *Series[X, Y]) Compact() {
 	if len(s.points) < 2 {
 		return
 	}
 	s.sort()
-	newSorted := []int64{s.sorted[0]}
+	newSorted := []X{s.sorted[0]}
 	lastY := s.points[s.sorted[0]]
 	for i, x := range s.sorted {
 		if i > 0 {
@@ -155,42 +182,48 @@ func (s *Series) Compact() {
 
 // Xs returns an ordered slice of all the x values of stored points.
 // Use this method along with Get() to iterate through (x, f(x)) in order.
-func (s *Series) Xs() []int64 {
+func (s *Series[X, Y]) Xs() []X {
 	s.sort()
-	xs := make([]int64, len(s.sorted))
+	xs := make([]X, len(s.sorted))
 	copy(xs, s.sorted)
 	return xs
 }
 
 // X0 returns the x value of the lowest stored point.  This is equivalent
-// to Xs[0].  Returns 0 if there are no stored points.
-func (s *Series) X0() int64 {
+// to Xs()[0].  Returns the zero value of X if there are no stored points.
+func (s *Series[X, Y]) X0() X {
 	s.sort()
 	if len(s.sorted) == 0 {
-		return 0
+		var zero X
+		return zero
 	}
 	return s.sorted[0]
 }
 
 // Floor returns the largest x₀ from the stored points such that x₀ ≤ x.
-// If there is no such x₀, then 0 is returned along with the ok = false.
-func (s *Series) Floor(x int64) (x0 int64, ok bool) {
+// If there is no such x₀, then the zero value of X is returned along with
+// ok = false.
+func (s *Series[X, Y]) Floor(x X) (x0 X, ok bool) {
 	if len(s.points) == 0 {
 		return
 	}
 
 	s.sort()
-	i := s.find(x)
-	if i < 0 {
+	i, found := s.find(x)
+	if found {
+		return s.sorted[i], true
+	}
+	if i == 0 {
 		return
 	}
 
-	return s.sorted[i], true
+	return s.sorted[i-1], true
 }
 
 // Ceiling returns the smallest x₁ from the stored points such that x₁ ≥ x.
-// If there is no such x₁ then 0 is returned along with ok = false.
-func (s *Series) Ceiling(x int64) (x1 int64, ok bool) {
+// If there is no such x₁ then the zero value of X is returned along with
+// ok = false.
+func (s *Series[X, Y]) Ceiling(x X) (x1 X, ok bool) {
 	if len(s.points) == 0 {
 		return
 	}
@@ -202,7 +235,7 @@ func (s *Series) Ceiling(x int64) (x1 int64, ok bool) {
 	}
 
 	s.sort()
-	i := s.find(x) + 1 // This finds the index of the smallest x₁ > x.
+	i, _ := s.find(x) // This finds the index of the smallest x₁ > x.
 	if i >= len(s.sorted) {
 		return
 	}
@@ -211,29 +244,30 @@ func (s *Series) Ceiling(x int64) (x1 int64, ok bool) {
 }
 
 // Copy returns a new Series which is a copy of s.
-func (s *Series) Copy() *Series {
+func (s *Series[X, Y]) Copy() *Series[X, Y] {
 	// Sort first to avoid having to sort twice later (once on s and once
 	// on the copy).  As a side effect, we now don't have to copy s.unsorted.
 	s.sort()
 
-	points := make(map[int64]int64)
+	points := make(map[X]Y)
 	for x, y := range s.points {
 		points[x] = y
 	}
 
-	sorted := make([]int64, len(s.sorted))
+	sorted := make([]X, len(s.sorted))
 	copy(sorted, s.sorted)
 
-	return &Series{
+	return &Series[X, Y]{
 		points:   points,
 		sorted:   sorted,
-		unsorted: make([]int64, 0),
+		unsorted: make([]X, 0),
+		cmp:      s.cmp,
 	}
 }
 
 // Equals returns true if s and s0 have the same set of stored points.
-// Equals does *not* ignore redundant points, and it generally advisable
+// Equals does *not* ignore redundant points, and it is generally advisable
 // to compact both series before checking equality.
-func (s *Series) Equals(s0 *Series) bool {
+func (s *Series[X, Y]) Equals(s0 *Series[X, Y]) bool {
 	return reflect.DeepEqual(s.points, s0.points)
 }
@@ -0,0 +1,40 @@
+package traces
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// benchmarkSetGet builds a series of n points, then repeatedly interleaves
+// a small batch of batch Set calls with a Get, simulating the common
+// pattern of a few writes followed by a read.
+func benchmarkSetGet(b *testing.B, n, batch int) {
+	r := rand.New(rand.NewSource(1))
+
+	base := make(map[int64]int64, n)
+	for len(base) < n {
+		x := r.Int63n(int64(n) * 10)
+		base[x] = r.Int63()
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		s := NewInt64SeriesData(base)
+		s.Xs() // force an initial sort so the benchmark measures steady state
+		b.StartTimer()
+
+		for j := 0; j < batch; j++ {
+			x := r.Int63n(int64(n) * 10)
+			s.Set(x, r.Int63())
+		}
+		s.Get(r.Int63n(int64(n) * 10))
+	}
+}
+
+func BenchmarkSetGet1k_1(b *testing.B)    { benchmarkSetGet(b, 1_000, 1) }
+func BenchmarkSetGet1k_10(b *testing.B)   { benchmarkSetGet(b, 1_000, 10) }
+func BenchmarkSetGet10k_1(b *testing.B)   { benchmarkSetGet(b, 10_000, 1) }
+func BenchmarkSetGet10k_10(b *testing.B)  { benchmarkSetGet(b, 10_000, 10) }
+func BenchmarkSetGet100k_1(b *testing.B)  { benchmarkSetGet(b, 100_000, 1) }
+func BenchmarkSetGet100k_10(b *testing.B) { benchmarkSetGet(b, 100_000, 10) }
@@ -0,0 +1,130 @@
+package traces
+
+import (
+	"testing"
+	"time"
+)
+
+// assertConsistentAny is the generic counterpart of assertConsistent, for
+// exercising Series instantiations other than Int64Series.
+func assertConsistentAny[X comparable, Y Number](t *testing.T, s *Series[X, Y]) {
+	matcher := make(map[X]struct{})
+	for x := range s.points {
+		matcher[x] = struct{}{}
+	}
+	for _, x := range s.sorted {
+		if _, ok := matcher[x]; ok {
+			delete(matcher, x)
+		} else {
+			t.Errorf("Inconsistent series: x-value %v from sorted not in points.", x)
+		}
+	}
+	for _, x := range s.unsorted {
+		if _, ok := matcher[x]; ok {
+			delete(matcher, x)
+		} else {
+			t.Errorf("Inconsistent series: x-value %v from unsorted not in points.", x)
+		}
+	}
+	for x := range matcher {
+		t.Errorf("Inconsistent series: x-value %v in neither sorted nor unsorted", x)
+	}
+	for i, x := range s.sorted {
+		if i > 0 && s.cmp(s.sorted[i-1], x) >= 0 {
+			t.Errorf("Inconsistent series: sorted is not in order (%v ≮ %v)",
+				s.sorted[i-1], x)
+		}
+	}
+}
+
+// TestGenericNumberSeries exercises NewSeries with x and y types other than
+// int64, to make sure the generic Set/Get/Floor/Ceiling/Compact paths work
+// at other numeric precisions, not just the int64 instantiation the rest of
+// the tests use.
+func TestGenericNumberSeries(t *testing.T) {
+	s := NewSeries[int32, uint64]()
+	assertConsistentAny(t, s)
+
+	s.Set(10, 100)
+	s.Set(20, 200)
+	s.Set(30, 200)
+	assertConsistentAny(t, s)
+
+	assert(t, "Get(10)", uint64(100), s.Get(10))
+	assert(t, "Get(15)", uint64(100), s.Get(15))
+	assert(t, "Get(25)", uint64(200), s.Get(25))
+	assert(t, "Get(5)", uint64(0), s.Get(5))
+
+	x0, ok := s.Floor(25)
+	assert(t, "Floor(25) ok", true, ok)
+	assert(t, "Floor(25)", int32(20), x0)
+
+	x1, ok := s.Ceiling(25)
+	assert(t, "Ceiling(25) ok", true, ok)
+	assert(t, "Ceiling(25)", int32(30), x1)
+
+	s.Compact()
+	assertConsistentAny(t, s)
+	assert(t, "Size after Compact", 2, s.Size())
+	assert(t, "Get(25) after Compact", uint64(200), s.Get(25))
+}
+
+// TestSeriesFunc exercises NewSeriesFunc with an x-axis type, time.Time,
+// that isn't cmp.Ordered, to make sure the comparator threaded through
+// sort/find/Floor/Ceiling is actually exercised and not just compiled.
+func TestSeriesFunc(t *testing.T) {
+	day := func(n int) time.Time {
+		return time.Date(2024, time.January, n, 0, 0, 0, 0, time.UTC)
+	}
+
+	s := NewSeriesFunc[time.Time, float64](func(a, b time.Time) int {
+		return a.Compare(b)
+	})
+	assertConsistentAny(t, s)
+
+	s.Set(day(1), 1.5)
+	s.Set(day(3), 2.5)
+	s.Set(day(5), 2.5)
+	assertConsistentAny(t, s)
+
+	assert(t, "Get(day1)", 1.5, s.Get(day(1)))
+	assert(t, "Get(day2)", 1.5, s.Get(day(2)))
+	assert(t, "Get(day4)", 2.5, s.Get(day(4)))
+	assert(t, "Get(day0)", 0.0, s.Get(day(0)))
+
+	x0, ok := s.Floor(day(4))
+	assert(t, "Floor(day4) ok", true, ok)
+	assert(t, "Floor(day4)", day(3), x0)
+
+	x1, ok := s.Ceiling(day(4))
+	assert(t, "Ceiling(day4) ok", true, ok)
+	assert(t, "Ceiling(day4)", day(5), x1)
+
+	s.Compact()
+	assertConsistentAny(t, s)
+	assert(t, "Size after Compact", 2, s.Size())
+	assert(t, "Get(day5) after Compact", 2.5, s.Get(day(5)))
+
+	var got []time.Time
+	for x := range s.RangeFunc(day(1), day(5), func(a, b time.Time) int { return a.Compare(b) }) {
+		got = append(got, x)
+	}
+	want := []time.Time{day(1), day(3)}
+	assert(t, "RangeFunc", want, got)
+}
+
+// TestStepFloat exercises Step over a float64 series, rather than only the
+// int64 series the other iterator tests use.
+func TestStepFloat(t *testing.T) {
+	s := NewSeries[float64, float64]()
+	s.Set(0, 1)
+	s.Set(2.5, 2)
+	s.Set(5, 3)
+
+	var got []float64
+	for _, y := range Step(s, 0, 5, 1.5) {
+		got = append(got, y)
+	}
+	want := []float64{1, 1, 2, 2}
+	assert(t, "Step over float64 series", want, got)
+}